@@ -0,0 +1,73 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// leafPaths returns the dotted path (e.g. "Writable.LogLevel") of every leaf field reachable from
+// serviceConfig, which must be a pointer to a struct. It mirrors the path naming environment.Variables
+// uses. It exists for sources (file, remote) whose underlying libraries populate serviceConfig directly,
+// leaving no per-field record of what they actually set - such a source has to attribute every leaf path
+// to itself, which is accurate as long as it runs before any higher-precedence source in the Resolver.
+func leafPaths(serviceConfig interface{}) []string {
+	return collectLeafPaths(reflect.ValueOf(serviceConfig).Elem(), nil)
+}
+
+func collectLeafPaths(value reflect.Value, prefix []string) []string {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		var paths []string
+		valueType := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			field := valueType.Field(i)
+			if field.PkgPath != "" {
+				continue // unexported
+			}
+			paths = append(paths, collectLeafPaths(value.Field(i), appendPath(prefix, field.Name))...)
+		}
+		return paths
+
+	case reflect.Map:
+		var paths []string
+		for _, key := range value.MapKeys() {
+			keyName := fmt.Sprintf("%v", key.Interface())
+			paths = append(paths, collectLeafPaths(value.MapIndex(key), appendPath(prefix, keyName))...)
+		}
+		return paths
+
+	default:
+		return []string{strings.Join(prefix, ".")}
+	}
+}
+
+// appendPath returns prefix with segment appended, copying so sibling branches of the recursive walk
+// above never share (and corrupt) the same backing array.
+func appendPath(prefix []string, segment string) []string {
+	path := make([]string, len(prefix)+1)
+	copy(path, prefix)
+	path[len(prefix)] = segment
+	return path
+}
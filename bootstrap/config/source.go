@@ -0,0 +1,83 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package config provides a pluggable configuration source layer sitting above the environment
+// package's reflection-based overrides. Where environment.Variables is a single source of overrides
+// (the environment), a Source is any one of several places a configuration value might come from, and
+// a Resolver merges them with a documented precedence so services no longer have to wire env overrides,
+// file loading and registry fetches together ad-hoc in bootstrap.
+package config
+
+import "fmt"
+
+// Source represents a single configuration layer that can supply values for some or all of a service's
+// configuration. serviceConfig, passed to Load, must be a pointer to the service's configuration struct
+// and is expected to already hold whatever a lower-precedence Source (or the struct's own zero values)
+// left in it.
+type Source interface {
+	// Name identifies the source for logging and Provenance reporting, e.g. "environment" or
+	// "file:/res/configuration.toml".
+	Name() string
+
+	// Load applies whatever values this source has to serviceConfig and returns the dotted
+	// configuration paths (e.g. "Writable.LogLevel") it supplied a value for.
+	Load(serviceConfig interface{}) ([]string, error)
+}
+
+// Provenance records, for a resolved configuration path, the name of the Source that supplied its final
+// value. A path with no entry was not reported by any Source. EnvironmentSource does not report the paths
+// it sets from envDefault struct tags, so such a path keeps the entry of an earlier Source that reported
+// it, or has no entry, even though its final value came from the tag.
+type Provenance map[string]string
+
+// Resolver merges any number of Source implementations into a single service configuration struct.
+type Resolver struct {
+	// sources is ordered lowest-precedence first, the order Resolve applies them in, so that a later
+	// source naturally overwrites an earlier one both in serviceConfig and in the returned Provenance.
+	sources []Source
+}
+
+// NewResolver builds a Resolver from sources listed highest-precedence first, e.g.
+//
+// 	NewResolver(flagSource, environmentSource, remoteSource, fileSource, defaultsSource)
+//
+// which documents and enforces the standard EdgeX precedence: flags > environment > remote > file >
+// defaults. Sources are applied in the reverse of this order so later, higher-precedence sources win.
+func NewResolver(sources ...Source) *Resolver {
+	reversed := make([]Source, len(sources))
+	for i, source := range sources {
+		reversed[len(sources)-1-i] = source
+	}
+
+	return &Resolver{sources: reversed}
+}
+
+// Resolve runs every configured Source over serviceConfig in precedence order and returns a Provenance
+// recording which source supplied each path's final value.
+func (r *Resolver) Resolve(serviceConfig interface{}) (Provenance, error) {
+	provenance := make(Provenance)
+
+	for _, source := range r.sources {
+		paths, err := source.Load(serviceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("configuration source '%s' failed: %s", source.Name(), err.Error())
+		}
+
+		for _, path := range paths {
+			provenance[path] = source.Name()
+		}
+	}
+
+	return provenance, nil
+}
@@ -0,0 +1,128 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+)
+
+// fakeSource is a minimal Source used to test Resolver's precedence and provenance logic in isolation
+// from any real source's file/environment/registry access.
+type fakeSource struct {
+	name  string
+	paths []string
+	err   error
+}
+
+func (f *fakeSource) Name() string {
+	return f.name
+}
+
+func (f *fakeSource) Load(_ interface{}) ([]string, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.paths, nil
+}
+
+func TestResolverAppliesHigherPrecedenceSourceLast(t *testing.T) {
+	flags := &fakeSource{name: "flags", paths: []string{"Writable.LogLevel"}}
+	env := &fakeSource{name: "environment", paths: []string{"Writable.LogLevel", "Service.Port"}}
+	defaults := &fakeSource{name: "defaults", paths: []string{"Writable.LogLevel", "Service.Port", "Service.Host"}}
+
+	resolver := NewResolver(flags, env, defaults)
+
+	provenance, err := resolver.Resolve(&struct{}{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "flags", provenance["Writable.LogLevel"])
+	assert.Equal(t, "environment", provenance["Service.Port"])
+	assert.Equal(t, "defaults", provenance["Service.Host"])
+}
+
+func TestResolverLeavesUnsuppliedPathsOutOfProvenance(t *testing.T) {
+	env := &fakeSource{name: "environment", paths: []string{"Writable.LogLevel"}}
+
+	resolver := NewResolver(env)
+
+	provenance, err := resolver.Resolve(&struct{}{})
+
+	require.NoError(t, err)
+	_, found := provenance["Service.Port"]
+	assert.False(t, found)
+}
+
+func TestResolverFailsFastOnSourceError(t *testing.T) {
+	failing := &fakeSource{name: "remote:consul", err: fmt.Errorf("connection refused")}
+
+	resolver := NewResolver(failing)
+
+	_, err := resolver.Resolve(&struct{}{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote:consul")
+}
+
+type resolverTestConfig struct {
+	Writable struct {
+		LogLevel string
+	}
+}
+
+// setTestEnv sets key for the duration of the test, restoring the previous value (or unsetting it if
+// there wasn't one) once the test completes.
+func setTestEnv(t *testing.T, key string, value string) {
+	old, existed := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if existed {
+			_ = os.Setenv(key, old)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+// EnvironmentSource and FileSource must report the same, proper-cased dotted path for the same field
+// (here "Writable.LogLevel") or a field set by both ends up with two Provenance entries that disagree
+// about which source actually won - FileSource's surviving as "file" even though EnvironmentSource, which
+// runs after it, supplied the real final value.
+func TestResolverUnifiesEnvironmentAndFileProvenanceKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "configuration.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"Writable":{"LogLevel":"INFO"}}`), 0600))
+
+	setTestEnv(t, "EDGEX_WRITABLE_LOGLEVEL", "DEBUG")
+
+	resolver := NewResolver(NewEnvironmentSource(logger.NewMockClient()), NewFileSource(path))
+
+	cfg := &resolverTestConfig{}
+	provenance, err := resolver.Resolve(cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG", cfg.Writable.LogLevel)
+	assert.Equal(t, "environment", provenance["Writable.LogLevel"])
+	assert.Len(t, provenance, 1)
+}
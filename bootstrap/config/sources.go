@@ -0,0 +1,186 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/edgexfoundry/go-mod-configuration/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/pkg/types"
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/bootstrap/environment"
+)
+
+// FlagSource applies already-parsed command-line flag values, addressed by the same dotted
+// configuration path OverrideConfigurationPaths reports (e.g. "Writable.LogLevel"), without the EDGEX_
+// prefix or "_"-joining environment variable names use. Services remain responsible for defining and
+// parsing their own flags; FlagSource only applies the resulting path/value pairs.
+type FlagSource struct {
+	values map[string]string
+}
+
+// NewFlagSource builds a FlagSource from a map of dotted configuration path to the flag value meant to
+// override it.
+func NewFlagSource(values map[string]string) *FlagSource {
+	return &FlagSource{values: values}
+}
+
+func (f *FlagSource) Name() string {
+	return "flags"
+}
+
+// Load applies every path/value pair in f.values to serviceConfig.
+func (f *FlagSource) Load(serviceConfig interface{}) ([]string, error) {
+	var applied []string
+	for dottedPath, value := range f.values {
+		path := strings.Split(dottedPath, ".")
+
+		overridden, err := environment.SetConfigValue(serviceConfig, path, value)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply flag override for '%s': %s", dottedPath, err.Error())
+		}
+		if overridden {
+			applied = append(applied, dottedPath)
+		}
+	}
+
+	return applied, nil
+}
+
+// EnvironmentSource adapts environment.Variables - both BindStruct's tag-driven binding and
+// OverrideConfigurationPaths' EDGEX_-prefixed path overrides - to the Source interface.
+type EnvironmentSource struct {
+	variables *environment.Variables
+	lc        logger.LoggingClient
+}
+
+// NewEnvironmentSource builds an EnvironmentSource over the current process environment.
+func NewEnvironmentSource(lc logger.LoggingClient) *EnvironmentSource {
+	return &EnvironmentSource{variables: environment.NewVariables(), lc: lc}
+}
+
+func (e *EnvironmentSource) Name() string {
+	return "environment"
+}
+
+func (e *EnvironmentSource) Load(serviceConfig interface{}) ([]string, error) {
+	if err := e.variables.BindStruct(e.lc, serviceConfig); err != nil {
+		return nil, err
+	}
+
+	return e.variables.OverrideConfigurationPaths(e.lc, serviceConfig)
+}
+
+// RemoteSource loads configuration from a Consul/registry provider via go-mod-configuration.
+type RemoteSource struct {
+	providerInfo types.ServiceConfig
+}
+
+// NewRemoteSource builds a RemoteSource that fetches configuration from the given provider.
+func NewRemoteSource(providerInfo types.ServiceConfig) *RemoteSource {
+	return &RemoteSource{providerInfo: providerInfo}
+}
+
+func (r *RemoteSource) Name() string {
+	return "remote:" + r.providerInfo.Type
+}
+
+func (r *RemoteSource) Load(serviceConfig interface{}) ([]string, error) {
+	client, err := configuration.NewConfigurationClient(r.providerInfo)
+	if err != nil {
+		return nil, fmt.Errorf("could not create configuration provider client: %s", err.Error())
+	}
+
+	hasConfig, err := client.HasConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("could not check for existing remote configuration: %s", err.Error())
+	}
+	if !hasConfig {
+		return nil, nil
+	}
+
+	if _, err := client.GetConfiguration(serviceConfig); err != nil {
+		return nil, fmt.Errorf("could not load remote configuration: %s", err.Error())
+	}
+
+	// The client fills in serviceConfig directly, with no per-field record of what it actually found
+	// in the registry, so (as with FileSource) every leaf path is attributed to this source. Put
+	// RemoteSource ahead of any source that should be able to override an individual field.
+	return leafPaths(serviceConfig), nil
+}
+
+// FileSource loads configuration from a local TOML, YAML or JSON file, chosen by the file's extension
+// (.toml; .yaml/.yml; .json).
+type FileSource struct {
+	path string
+}
+
+// NewFileSource builds a FileSource that reads the configuration file at path.
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+func (f *FileSource) Name() string {
+	return "file:" + f.path
+}
+
+func (f *FileSource) Load(serviceConfig interface{}) ([]string, error) {
+	contents, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read configuration file '%s': %s", f.path, err.Error())
+	}
+
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contents, serviceConfig)
+	case ".json":
+		err = json.Unmarshal(contents, serviceConfig)
+	default:
+		err = toml.Unmarshal(contents, serviceConfig)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse configuration file '%s': %s", f.path, err.Error())
+	}
+
+	// As with RemoteSource, the decoder fills in serviceConfig directly so every leaf path is
+	// attributed to this source.
+	return leafPaths(serviceConfig), nil
+}
+
+// DefaultsSource is a no-op placeholder for the lowest-precedence layer in a Resolver: the in-code
+// defaults a service already set on its configuration struct before Resolve was called. It exists so
+// that precedence chain is documented and complete even though there's nothing for it to do.
+type DefaultsSource struct{}
+
+// NewDefaultsSource builds a DefaultsSource.
+func NewDefaultsSource() *DefaultsSource {
+	return &DefaultsSource{}
+}
+
+func (d *DefaultsSource) Name() string {
+	return "defaults"
+}
+
+func (d *DefaultsSource) Load(_ interface{}) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,324 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+)
+
+const (
+	envTagName          = "env"
+	envDefaultTagName   = "envDefault"
+	envRequiredTagName  = "envRequired"
+	envSeparatorTagName = "envSeparator"
+	defaultEnvSeparator = ","
+)
+
+// BindStruct binds the fields of cfg, which must be a pointer to a struct, to environment variables
+// using `env`, `envDefault`, `envRequired` and `envSeparator` struct tags, in the style popularized by
+// envconfig/caarlos0-env. An explicit `env:"NAME"` tag takes precedence over the EDGEX_-prefixed path
+// name that OverrideConfiguration would otherwise derive for the same field (e.g. Writable.LogLevel
+// becomes EDGEX_WRITABLE_LOGLEVEL). Fields for which no environment variable and no envDefault are
+// found are left untouched, so BindStruct can safely run before OverrideConfiguration to fill in
+// values the config file doesn't provide. All missing envRequired fields are collected and returned
+// together rather than failing on the first one, so services can report every missing setting at once.
+func (e *Variables) BindStruct(lc logger.LoggingClient, cfg interface{}) error {
+	e.overrides = nil
+	e.continueOverridePass = true
+
+	errs := &multiError{}
+	e.bindValue(lc, reflect.ValueOf(cfg).Elem(), nil, errs)
+	if len(errs.errors) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// bindValue walks target (a struct), binding each leaf field and descending into nested structs so that
+// deeply nested fields can carry their own env/envDefault/envRequired/envSeparator tags.
+func (e *Variables) bindValue(lc logger.LoggingClient, target reflect.Value, path []string, errs *multiError) {
+	target = dereferenceOrAllocate(target)
+	if target.Kind() != reflect.Struct {
+		return
+	}
+
+	targetType := target.Type()
+	for i := 0; i < targetType.NumField(); i++ {
+		fieldType := targetType.Field(i)
+		field := target.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		fieldPath := append(append([]string{}, path...), fieldType.Name)
+
+		if isBindableLeaf(field) {
+			e.bindField(lc, field, fieldType, fieldPath, errs)
+			continue
+		}
+
+		nested := dereferenceOrAllocate(field)
+		if nested.Kind() == reflect.Struct {
+			e.bindValue(lc, field, fieldPath, errs)
+		}
+	}
+}
+
+// bindField resolves the environment value for a single leaf field and, if one is found, applies it.
+func (e *Variables) bindField(
+	lc logger.LoggingClient,
+	field reflect.Value,
+	fieldType reflect.StructField,
+	path []string,
+	errs *multiError) {
+
+	envKey, explicit := fieldType.Tag.Lookup(envTagName)
+	if !explicit || envKey == "" {
+		envKey = buildEnvKey(path)
+	}
+
+	value, fromEnv := os.LookupEnv(envKey)
+	found := fromEnv
+	if !found {
+		if defaultValue, ok := fieldType.Tag.Lookup(envDefaultTagName); ok {
+			value = defaultValue
+			found = true
+		}
+	}
+
+	if !found {
+		if isTrue(fieldType.Tag.Get(envRequiredTagName)) {
+			errs.add(fmt.Errorf("required environment variable %s for field %s is not set", envKey, strings.Join(path, ".")))
+		}
+		return
+	}
+
+	separator := fieldType.Tag.Get(envSeparatorTagName)
+	if separator == "" {
+		separator = defaultEnvSeparator
+	}
+
+	applied, err := e.setBoundValue(field, value, separator)
+	if err != nil {
+		errs.add(fmt.Errorf("environment value override failed for %s=%s: %s", envKey, value, err.Error()))
+		return
+	}
+
+	if !fromEnv {
+		// value came from envDefault, not an actual environment variable, so there's no real override
+		// to audit or log - the field is simply taking its documented default.
+		return
+	}
+
+	dottedPath := strings.Join(path, ".")
+	record, isNew := e.newOverrideRecord(dottedPath, envKey, value, applied)
+	if isNew {
+		logEnvironmentOverride(lc, dottedPath, envKey, record.RawValue)
+	}
+}
+
+// setBoundValue converts value (splitting on separator first for slice/map fields) and assigns it to
+// field, returning a record of what field held beforehand for the caller to build an OverrideRecord from.
+func (e *Variables) setBoundValue(field reflect.Value, value string, separator string) (*appliedValue, error) {
+	applied := &appliedValue{
+		previous:    fmt.Sprintf("%v", field.Interface()),
+		coercedType: field.Type().String(),
+	}
+
+	if unmarshaler, ok := textUnmarshaler(field); ok {
+		if err := unmarshaler.UnmarshalText([]byte(value)); err != nil {
+			return nil, err
+		}
+		return applied, nil
+	}
+
+	fieldType := field.Type()
+	switch fieldType {
+	case reflect.TypeOf(time.Duration(0)):
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		field.SetInt(int64(duration))
+		return applied, nil
+
+	case reflect.TypeOf(url.URL{}):
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		field.Set(reflect.ValueOf(*parsed))
+		return applied, nil
+
+	case reflect.TypeOf(net.IP{}):
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("'%s' is not a valid IP address", value)
+		}
+		field.Set(reflect.ValueOf(ip))
+		return applied, nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Slice:
+		if err := e.setBoundSlice(field, value, separator); err != nil {
+			return nil, err
+		}
+		return applied, nil
+	case reflect.Map:
+		if err := e.setBoundMap(field, value, separator); err != nil {
+			return nil, err
+		}
+		return applied, nil
+	default:
+		newValue, err := e.convertToType(field.Interface(), fieldType, value)
+		if err != nil {
+			return nil, err
+		}
+		field.Set(reflect.ValueOf(newValue))
+		return applied, nil
+	}
+}
+
+// setBoundSlice splits value on separator and converts each element to the slice's element type.
+func (e *Variables) setBoundSlice(field reflect.Value, value string, separator string) error {
+	elemType := field.Type().Elem()
+	parts := strings.Split(value, separator)
+	slice := reflect.MakeSlice(field.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		elemValue, err := e.convertToType(nil, elemType, strings.TrimSpace(part))
+		if err != nil {
+			return err
+		}
+		slice.Index(i).Set(reflect.ValueOf(elemValue))
+	}
+
+	field.Set(slice)
+	return nil
+}
+
+// setBoundMap splits value on separator into "key:value" entries and converts each to the map's key/value
+// types, e.g. envSeparator:";" with value "one:1;two:2".
+func (e *Variables) setBoundMap(field reflect.Value, value string, separator string) error {
+	mapType := field.Type()
+	entries := strings.Split(value, separator)
+	newMap := reflect.MakeMapWithSize(mapType, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("'%s' is not a valid key:value map entry", entry)
+		}
+
+		keyValue, err := e.convertToType(nil, mapType.Key(), strings.TrimSpace(parts[0]))
+		if err != nil {
+			return err
+		}
+		elemValue, err := e.convertToType(nil, mapType.Elem(), strings.TrimSpace(parts[1]))
+		if err != nil {
+			return err
+		}
+
+		newMap.SetMapIndex(reflect.ValueOf(keyValue), reflect.ValueOf(elemValue))
+	}
+
+	field.Set(newMap)
+	return nil
+}
+
+// isBindableLeaf reports whether field should be bound directly from a single environment variable,
+// rather than descended into field-by-field.
+func isBindableLeaf(field reflect.Value) bool {
+	if _, ok := textUnmarshaler(field); ok {
+		return true
+	}
+
+	switch field.Type() {
+	case reflect.TypeOf(time.Duration(0)), reflect.TypeOf(url.URL{}), reflect.TypeOf(net.IP{}):
+		return true
+	}
+
+	switch field.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// textUnmarshaler returns field's value (or, if addressable, a pointer to it) as an encoding.TextUnmarshaler,
+// if it implements that interface.
+func textUnmarshaler(field reflect.Value) (encoding.TextUnmarshaler, bool) {
+	if unmarshaler, ok := field.Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler, true
+	}
+	if field.CanAddr() {
+		if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return unmarshaler, true
+		}
+	}
+
+	return nil, false
+}
+
+// buildEnvKey derives the EDGEX_-prefixed environment variable name for a field path the same way
+// OverrideConfiguration does, e.g. []string{"Writable", "LogLevel"} becomes "EDGEX_WRITABLE_LOGLEVEL".
+func buildEnvKey(path []string) string {
+	return envKeyValuePrefix + strings.ToUpper(strings.Join(path, "_"))
+}
+
+// isTrue parses an envRequired tag value, treating anything strconv can't parse as false.
+func isTrue(value string) bool {
+	result, _ := strconv.ParseBool(value)
+	return result
+}
+
+// multiError collects multiple errors - used so BindStruct can report every missing required field
+// in one pass instead of stopping at the first one.
+type multiError struct {
+	errors []error
+}
+
+func (m *multiError) add(err error) {
+	m.errors = append(m.errors, err)
+}
+
+func (m *multiError) Error() string {
+	messages := make([]string, len(m.errors))
+	for i, err := range m.errors {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+)
+
+type bindTestConfig struct {
+	LogLevel string `env:"TEST_LOG_LEVEL" envDefault:"INFO"`
+	Port     int    `envDefault:"8080"`
+	Host     string `envRequired:"true"`
+	Tags     []string
+}
+
+// setTestEnv sets key for the duration of the test, restoring the previous value (or unsetting it if
+// there wasn't one) once the test completes.
+func setTestEnv(t *testing.T, key string, value string) {
+	old, existed := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if existed {
+			_ = os.Setenv(key, old)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func TestBindStructAppliesExplicitEnvTag(t *testing.T) {
+	setTestEnv(t, "TEST_LOG_LEVEL", "DEBUG")
+	setTestEnv(t, "EDGEX_HOST", "localhost")
+
+	cfg := &bindTestConfig{}
+	e := newTestVariables()
+
+	err := e.BindStruct(logger.NewMockClient(), cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, "DEBUG", cfg.LogLevel)
+
+	overrides := e.Overrides()
+	require.Len(t, overrides, 2)
+}
+
+func TestBindStructAppliesEnvDefaultWithoutRecordingOverride(t *testing.T) {
+	setTestEnv(t, "EDGEX_HOST", "localhost")
+
+	cfg := &bindTestConfig{}
+	e := newTestVariables()
+
+	err := e.BindStruct(logger.NewMockClient(), cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Port)
+
+	for _, record := range e.Overrides() {
+		assert.NotEqual(t, "Port", record.Key)
+	}
+}
+
+func TestBindStructReportsMissingRequiredField(t *testing.T) {
+	cfg := &bindTestConfig{}
+	e := newTestVariables()
+
+	err := e.BindStruct(logger.NewMockClient(), cfg)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "EDGEX_HOST")
+}
+
+func TestBindStructParsesSliceField(t *testing.T) {
+	setTestEnv(t, "EDGEX_HOST", "localhost")
+	setTestEnv(t, "EDGEX_TAGS", "one,two,three")
+
+	cfg := &bindTestConfig{}
+	e := newTestVariables()
+
+	err := e.BindStruct(logger.NewMockClient(), cfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two", "three"}, cfg.Tags)
+}
+
+// dedupTestConfig's Host field has no explicit env tag, so BindStruct derives the same EDGEX_HOST key for
+// it that OverrideConfigurationPaths would also derive by walking the same environment variable.
+type dedupTestConfig struct {
+	Host string `envRequired:"true"`
+}
+
+// EnvironmentSource.Load runs BindStruct then OverrideConfigurationPaths over the same Variables and the
+// same environment variables, so a field matched by both must be recorded (and logged) once, not twice -
+// which only holds if both passes agree on the dotted Key's casing (BindStruct's is proper-cased via the
+// struct's actual field names; OverrideConfigurationPaths' must match it).
+func TestBindStructAndOverrideConfigurationPathsAgreeOnKeyCasing(t *testing.T) {
+	setTestEnv(t, "EDGEX_HOST", "localhost")
+
+	cfg := &dedupTestConfig{}
+	e := newTestVariables()
+	e.variables = map[string]string{"EDGEX_HOST": "localhost"}
+
+	require.NoError(t, e.BindStruct(logger.NewMockClient(), cfg))
+	_, err := e.OverrideConfigurationPaths(logger.NewMockClient(), cfg)
+	require.NoError(t, err)
+
+	overrides := e.Overrides()
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "Host", overrides[0].Key)
+}
@@ -0,0 +1,146 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+)
+
+func TestResolveFileVariablesExpandsFileSuffixedVar(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(secretPath, []byte("s3cr3t\n"), 0600))
+
+	e := &Variables{
+		variables: map[string]string{
+			"EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD_FILE": secretPath,
+		},
+		redactPatterns: defaultRedactPatterns,
+	}
+
+	resolved := e.resolveFileVariables(logger.NewMockClient())
+
+	assert.Equal(t, "s3cr3t", resolved["EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD"])
+	_, stillPresent := resolved["EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD_FILE"]
+	assert.False(t, stillPresent)
+}
+
+func TestResolveFileVariablesIgnoresReservedConfigFileVar(t *testing.T) {
+	e := &Variables{
+		variables: map[string]string{
+			envFile: "/some/path.toml",
+		},
+		redactPatterns: defaultRedactPatterns,
+	}
+
+	resolved := e.resolveFileVariables(logger.NewMockClient())
+
+	assert.Equal(t, "/some/path.toml", resolved[envFile])
+}
+
+func TestResolveFileVariablesIgnoresNonEdgeXFileVar(t *testing.T) {
+	e := &Variables{
+		variables: map[string]string{
+			"POSTGRES_PASSWORD_FILE": "/does/not/exist",
+		},
+		redactPatterns: defaultRedactPatterns,
+	}
+
+	resolved := e.resolveFileVariables(logger.NewMockClient())
+
+	assert.Equal(t, "/does/not/exist", resolved["POSTGRES_PASSWORD_FILE"])
+	_, created := resolved["POSTGRES_PASSWORD"]
+	assert.False(t, created)
+}
+
+func TestResolveFileVariablesUnreadableFileDoesNotFailPass(t *testing.T) {
+	e := &Variables{
+		variables: map[string]string{
+			"EDGEX_WRITABLE_LOGLEVEL_FILE": "/no/such/file",
+			"EDGEX_WRITABLE_PORT":          "8080",
+		},
+		redactPatterns: defaultRedactPatterns,
+	}
+
+	resolved := e.resolveFileVariables(logger.NewMockClient())
+
+	_, created := resolved["EDGEX_WRITABLE_LOGLEVEL"]
+	assert.False(t, created)
+	assert.Equal(t, "8080", resolved["EDGEX_WRITABLE_PORT"])
+}
+
+func TestResolveFileValuePrefersFileOverPlainValue(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "confdir")
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("/etc/edgex"), 0600))
+	setTestEnv(t, envConfDir+fileSuffix, filePath)
+
+	value, err := resolveFileValue(logger.NewMockClient(), envConfDir, "./res")
+
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/edgex", value)
+}
+
+func TestResolveFileValueFallsBackWhenFileVarNotSet(t *testing.T) {
+	value, err := resolveFileValue(logger.NewMockClient(), envConfDir, "./res")
+
+	require.NoError(t, err)
+	assert.Equal(t, "./res", value)
+}
+
+// capturingLogger records every message passed to Info/Error so a test can assert on what was (or, more
+// importantly, wasn't) logged. logger.MockLogger discards messages, which can't prove a secret was kept
+// out of the log.
+type capturingLogger struct {
+	logger.LoggingClient
+	messages []string
+}
+
+func (c *capturingLogger) Info(msg string, _ ...interface{}) {
+	c.messages = append(c.messages, msg)
+}
+
+func (c *capturingLogger) Error(msg string, _ ...interface{}) {
+	c.messages = append(c.messages, msg)
+}
+
+func TestResolveFileVariablesDoesNotLogPlainValueItReplaces(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(secretPath, []byte("s3cr3t"), 0600))
+
+	e := &Variables{
+		variables: map[string]string{
+			"EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD":      "old-plaintext-secret",
+			"EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD_FILE": secretPath,
+		},
+		redactPatterns: defaultRedactPatterns,
+	}
+	lc := &capturingLogger{LoggingClient: logger.NewMockClient()}
+
+	resolved := e.resolveFileVariables(lc)
+
+	assert.Equal(t, "s3cr3t", resolved["EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD"])
+	for _, message := range lc.messages {
+		assert.NotContains(t, message, "old-plaintext-secret")
+	}
+}
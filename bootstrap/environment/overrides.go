@@ -0,0 +1,123 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import "path"
+
+// redactedPlaceholder replaces RawValue and PreviousValue in an OverrideRecord whose Key matches one of
+// the redact patterns, so the record itself is always safe to log or publish even when Redacted is true.
+const redactedPlaceholder = "*REDACTED*"
+
+// defaultRedactPatterns are the path.Match glob patterns (matched against an OverrideRecord's dotted Key)
+// whose values get redacted unless a service calls SetRedactPatterns with its own list.
+var defaultRedactPatterns = []string{"*Secrets*", "*Password*", "*Token*", "*Key*"}
+
+// OverrideRecord is a structured audit record of a single environment-variable-driven configuration
+// override, suitable for emitting as a JSON event or publishing via a message bus, in place of the
+// free-form info line logEnvironmentOverride previously produced alone.
+type OverrideRecord struct {
+	// Key is the dotted configuration path that was overridden, e.g. "Writable.LogLevel".
+	Key string
+	// EnvVarName is the environment variable (possibly named by an env struct tag) that supplied the value.
+	EnvVarName string
+	// RawValue is the string value of EnvVarName, or redactedPlaceholder if Redacted is true.
+	RawValue string
+	// CoercedType is the Go type the value was converted to, e.g. "string" or "time.Duration".
+	CoercedType string
+	// PreviousValue is what Key held immediately before the override, or redactedPlaceholder if
+	// Redacted is true.
+	PreviousValue string
+	// Redacted is true when Key matched one of the configured redact patterns.
+	Redacted bool
+}
+
+// appliedValue is what setLeafValue/overrideMapValue/setBoundValue return on a successful override, for
+// the caller to turn into an OverrideRecord. A nil *appliedValue (with a nil error) means nothing in
+// serviceConfig corresponded to the environment variable, so no override happened.
+type appliedValue struct {
+	previous    string
+	coercedType string
+}
+
+// SetRedactPatterns replaces the default glob patterns (path.Match syntax, matched against a dotted
+// configuration Key) used to decide which OverrideRecord values get redacted. The defaults are
+// "*Secrets*", "*Password*", "*Token*" and "*Key*".
+func (e *Variables) SetRedactPatterns(patterns []string) {
+	e.redactPatterns = patterns
+}
+
+// Overrides returns every OverrideRecord captured by this Variables' most recent override pass: a call to
+// BindStruct, optionally followed by the OverrideConfigurationPaths/OverrideConfiguration call that
+// typically completes it (see config.EnvironmentSource), which is treated as one pass rather than two so
+// a field bound by both an env tag and its EDGEX_ path is only recorded once.
+func (e *Variables) Overrides() []OverrideRecord {
+	return e.overrides
+}
+
+// resetOverrides clears e.overrides to start a new override pass. BindStruct always starts a pass;
+// OverrideConfigurationPaths starts one too unless BindStruct just ran on this Variables and left
+// continueOverridePass set, so the two can be composed into a single pass without one discarding the
+// other's records.
+func (e *Variables) resetOverrides() {
+	if e.continueOverridePass {
+		e.continueOverridePass = false
+		return
+	}
+
+	e.overrides = nil
+}
+
+// isRedacted reports whether key matches one of e.redactPatterns.
+func (e *Variables) isRedacted(key string) bool {
+	for _, pattern := range e.redactPatterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newOverrideRecord builds the OverrideRecord for a successful override, redacting RawValue and
+// PreviousValue when key matches a redact pattern, and records it in e.overrides. If a record for key is
+// already present - e.g. because BindStruct and OverrideConfigurationPaths both matched the same field in
+// one override pass - it is replaced in place rather than duplicated. isNew reports whether this is the
+// first record for key in the current pass, so callers can avoid logging the same override twice.
+func (e *Variables) newOverrideRecord(key string, envVarName string, rawValue string, applied *appliedValue) (record OverrideRecord, isNew bool) {
+	record = OverrideRecord{
+		Key:           key,
+		EnvVarName:    envVarName,
+		RawValue:      rawValue,
+		CoercedType:   applied.coercedType,
+		PreviousValue: applied.previous,
+		Redacted:      e.isRedacted(key),
+	}
+
+	if record.Redacted {
+		record.RawValue = redactedPlaceholder
+		record.PreviousValue = redactedPlaceholder
+	}
+
+	for i, existing := range e.overrides {
+		if existing.Key == key {
+			e.overrides[i] = record
+			return record, false
+		}
+	}
+
+	e.overrides = append(e.overrides, record)
+
+	return record, true
+}
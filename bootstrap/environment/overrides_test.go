@@ -0,0 +1,54 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
+)
+
+type redactTestSecretData struct {
+	Password string
+}
+
+type redactTestConfig struct {
+	Writable struct {
+		InsecureSecrets map[string]redactTestSecretData
+	}
+}
+
+// Environment variable names are always all-uppercase, but defaultRedactPatterns are mixed-case (e.g.
+// "*Password*"); OverrideConfigurationPaths must report a proper-cased dotted Key so path.Match still
+// recognizes a secret reached purely via the environment.
+func TestOverrideConfigurationPathsRedactsPasswordReachedFromEnvironment(t *testing.T) {
+	e := newTestVariables()
+	e.variables = map[string]string{
+		"EDGEX_WRITABLE_INSECURESECRETS_DB_PASSWORD": "s3cr3t",
+	}
+	cfg := &redactTestConfig{}
+
+	_, err := e.OverrideConfigurationPaths(logger.NewMockClient(), cfg)
+
+	require.NoError(t, err)
+	overrides := e.Overrides()
+	require.Len(t, overrides, 1)
+	assert.Equal(t, "Writable.InsecureSecrets.DB.Password", overrides[0].Key)
+	assert.True(t, overrides[0].Redacted)
+	assert.Equal(t, redactedPlaceholder, overrides[0].RawValue)
+}
@@ -17,15 +17,14 @@ package environment
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/edgexfoundry/go-mod-configuration/pkg/types"
 	"github.com/edgexfoundry/go-mod-core-contracts/clients/logger"
-	"github.com/pelletier/go-toml"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/bootstrap/logging"
 )
@@ -35,6 +34,7 @@ const (
 	bootRetrySecondsDefault   = 1
 	defaultConfDirValue       = "./res"
 
+	envKeyValuePrefix       = "EDGEX_"
 	envKeyConfigUrl         = "EDGEX_CONFIGURATION_PROVIDER"
 	envKeyRegistryUrl       = "edgex_registry"   // TODO: Remove for release v2.0.0
 	envV1KeyStartupDuration = "startup_duration" // TODO: Remove for release v2.0.0
@@ -45,10 +45,29 @@ const (
 	envV1Profile            = "edgex_profile" // TODO: Remove for release v2.0.0
 	envProfile              = "EDGEX_PROFILE"
 	envFile                 = "EDGEX_CONFIG_FILE"
+
+	// fileSuffix marks an environment variable, e.g. EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD_FILE,
+	// as holding a path to a file whose (trimmed) contents are the effective value for the variable of the
+	// same name without this suffix. This is the Docker/Postgres image convention for mounting secrets
+	// without exposing them in `docker inspect` or a process listing.
+	fileSuffix = "_FILE"
 )
 
-// Variables is receiver that holds Variables variables and encapsulates toml.Tree-based configuration field
-// overrides.  Assumes "_" embedded in Variables variable key separates substructs; e.g. foo_bar_baz might refer to
+// reservedFileVars are fixed EDGEX_*_FILE names that are not configuration-path overrides: EDGEX_CONFIG_FILE
+// names the configuration file itself (read by GetConfigFileName), while EDGEX_CONF_DIR_FILE and
+// EDGEX_PROFILE_FILE are resolved individually by resolveFileValue from GetConfDir and GetProfileDir.
+// resolveFileVariables must not also treat them as generic configuration overrides, since their "base"
+// name (EDGEX_CONFIG, EDGEX_CONF_DIR, EDGEX_PROFILE) doesn't correspond to anything in a service's
+// configuration struct.
+var reservedFileVars = map[string]struct{}{
+	envFile:                 {},
+	envConfDir + fileSuffix: {},
+	envProfile + fileSuffix: {},
+}
+
+// Variables is receiver that holds Variables variables and encapsulates the reflection-based configuration
+// field overrides. Assumes "_" embedded in an EDGEX_-prefixed Variables variable key separates substructs;
+// e.g. EDGEX_FOO_BAR_BAZ might refer to
 //
 // 		type foo struct {
 // 			bar struct {
@@ -57,13 +76,24 @@ const (
 //		}
 type Variables struct {
 	variables map[string]string
+	// overrides is the structured audit trail of every override OverrideConfiguration/
+	// OverrideConfigurationPaths/BindStruct has applied, retrievable via Overrides().
+	overrides []OverrideRecord
+	// redactPatterns is the set of path.Match glob patterns whose matching Keys get redacted in
+	// OverrideRecord, see SetRedactPatterns.
+	redactPatterns []string
+	// continueOverridePass is set by BindStruct so that the OverrideConfigurationPaths call that
+	// typically follows it in the same override pass (see config.EnvironmentSource) appends to, rather
+	// than resets, e.overrides. See resetOverrides.
+	continueOverridePass bool
 }
 
 // NewEnvironment constructor reads/stores os.Environ() for use by Variables receiver methods.
 func NewVariables() *Variables {
 	osEnv := os.Environ()
 	e := &Variables{
-		variables: make(map[string]string, len(osEnv)),
+		variables:      make(map[string]string, len(osEnv)),
+		redactPatterns: defaultRedactPatterns,
 	}
 	for _, env := range osEnv {
 		// Can not use Split() on '=' since the value may have an '=' in it, so changed to use Index()
@@ -87,106 +117,337 @@ func (e *Variables) UseRegistry() bool {
 }
 
 // OverrideConfiguration method replaces values in the configuration for matching Variables variable keys.
-// serviceConfig must be pointer to the service configuration.
+// serviceConfig must be pointer to the service configuration. Every environment variable name must be
+// prefixed with EDGEX_; the remainder of the name is split on "_" into a path and walked one element at a
+// time against the reflect representation of serviceConfig, the same way Docker Distribution walks its
+// configuration struct. Struct fields are matched case-insensitively and simply descended into. Map fields
+// (including map[string]interface{} used for plugin/driver configuration whose shape isn't known at compile
+// time) have their keys created on the fly if they don't already exist, which allows entries such as
+// Writable.InsecureSecrets.<name>.Path to be added purely from the environment. Once a leaf is reached the
+// string value is unmarshalled via convertToType. Any variable whose name ends in _FILE (see fileSuffix),
+// other than the reservedFileVars names, is treated as holding a path to a secret file rather than a
+// literal value.
 func (e *Variables) OverrideConfiguration(lc logger.LoggingClient, serviceConfig interface{}) (int, error) {
-	var overrideCount = 0
+	paths, err := e.OverrideConfigurationPaths(lc, serviceConfig)
+	return len(paths), err
+}
 
-	contents, err := toml.Marshal(reflect.ValueOf(serviceConfig).Elem().Interface())
-	if err != nil {
-		return 0, err
-	}
+// OverrideConfigurationPaths does the same work as OverrideConfiguration but additionally returns the
+// dotted configuration paths (e.g. "Writable.LogLevel") that were overridden, so that callers such as
+// config.Source can build a per-key provenance report.
+func (e *Variables) OverrideConfigurationPaths(lc logger.LoggingClient, serviceConfig interface{}) ([]string, error) {
+	e.resetOverrides()
 
-	configTree, err := toml.LoadBytes(contents)
-	if err != nil {
-		return 0, err
+	var overriddenPaths []string
+
+	configValue := reflect.ValueOf(serviceConfig).Elem()
+
+	variables := e.resolveFileVariables(lc)
+
+	for envVar, envValue := range variables {
+		if !strings.HasPrefix(envVar, envKeyValuePrefix) {
+			continue
+		}
+
+		path := strings.Split(strings.TrimPrefix(envVar, envKeyValuePrefix), "_")
+
+		matchedPath, applied, err := e.overrideValue(configValue, path, envVar, envValue)
+		if err != nil {
+			return nil, err
+		}
+		if applied == nil {
+			continue
+		}
+
+		dottedPath := strings.Join(matchedPath, ".")
+		overriddenPaths = append(overriddenPaths, dottedPath)
+
+		record, isNew := e.newOverrideRecord(dottedPath, envVar, envValue, applied)
+		if isNew {
+			logEnvironmentOverride(lc, dottedPath, envVar, record.RawValue)
+		}
 	}
 
-	// The toml.Tree API keys() only return to top level keys, rather that paths.
-	// It is also missing a GetPaths so have to spin our own
-	paths := e.buildPaths(configTree.ToMap())
-	// Now that we have all the paths in the config tree, we need to create a map that has the uppercase versions as
-	// the map keys and the original versions as the map values so we can match against uppercase names but use the
-	// originals to set values.
-	pathMap := e.buildUppercasePathMap(paths)
+	return overriddenPaths, nil
+}
+
+// SetConfigValue overrides a single value in serviceConfig addressed by path (e.g.
+// []string{"Writable", "LogLevel"}), using the same struct/map walking rules as OverrideConfiguration.
+// It is exported so that other configuration sources (see the config package) can apply one-off
+// overrides, such as command-line flags, without duplicating the struct-walking logic in this file.
+func SetConfigValue(serviceConfig interface{}, path []string, value string) (bool, error) {
+	var e Variables
+	_, applied, err := e.overrideValue(reflect.ValueOf(serviceConfig).Elem(), path, strings.Join(path, "."), value)
+	return applied != nil, err
+}
+
+// isConfigFileVar reports whether envVar is an EDGEX_*_FILE variable that resolveFileVariables should
+// expand into a configuration override, as opposed to an unrelated *_FILE variable elsewhere in the
+// process environment or one of the fixed reservedFileVars names that resolveFileValue already handles
+// individually.
+func isConfigFileVar(envVar string) bool {
+	if !strings.HasPrefix(envVar, envKeyValuePrefix) || !strings.HasSuffix(envVar, fileSuffix) {
+		return false
+	}
+	_, reserved := reservedFileVars[envVar]
+	return !reserved
+}
 
+// resolveFileVariables returns a copy of e.variables with every EDGEX_*_FILE entry replaced by the
+// corresponding EDGEX_* entry holding the trimmed contents of the file it points to. If both the plain
+// and _FILE forms are set, the _FILE form wins and an info message is logged noting the override. A
+// _FILE entry whose file can't be read is logged and otherwise ignored rather than failing the whole
+// override pass, since it may simply be unrelated to this service (e.g. a stray EDGEX_*_FILE variable in
+// the process environment). *_FILE variables without the EDGEX_ prefix, such as POSTGRES_PASSWORD_FILE,
+// are never read at all.
+func (e *Variables) resolveFileVariables(lc logger.LoggingClient) map[string]string {
+	resolved := make(map[string]string, len(e.variables))
 	for envVar, envValue := range e.variables {
-		envKey := strings.Replace(envVar, "_", ".", -1)
-		key, found := e.getKeyForMatchedPath(pathMap, envKey)
-		if !found {
+		if isConfigFileVar(envVar) {
 			continue
 		}
+		resolved[envVar] = envValue
+	}
 
-		oldValue := configTree.Get(key)
+	for envVar, filePath := range e.variables {
+		if !isConfigFileVar(envVar) {
+			continue
+		}
 
-		newValue, err := e.convertToType(oldValue, envValue)
+		baseVar := strings.TrimSuffix(envVar, fileSuffix)
+		content, err := ioutil.ReadFile(filePath)
 		if err != nil {
-			return 0, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
+			lc.Error(fmt.Sprintf("could not read file for environment variable %s: %s", envVar, err.Error()))
+			continue
 		}
 
-		configTree.Set(key, newValue)
-		overrideCount++
-		logEnvironmentOverride(lc, key, envVar, envValue)
+		if _, exists := resolved[baseVar]; exists {
+			// Note only that the _FILE value is taking precedence, never the plain value it replaces -
+			// baseVar may well be a secret (e.g. EDGEX_WRITABLE_INSECURESECRETS_DB_SECRETS_PASSWORD),
+			// and logging it here would defeat the whole point of the _FILE indirection.
+			lc.Info(fmt.Sprintf("environment variable %s overrides the plain value of %s", envVar, baseVar))
+		}
+
+		resolved[baseVar] = strings.TrimRight(string(content), "\r\n\t ")
 	}
 
-	// Put the configuration back into the services configuration struct with the overridden values
-	err = configTree.Unmarshal(serviceConfig)
+	return resolved
+}
+
+// resolveFileValue returns the contents of the file pointed to by the key+_FILE environment variable, if
+// it is set, overriding value (which wins over a plain key+_FILE miss). This is used by the handful of
+// helpers below that resolve a single, fixed environment variable rather than walking e.variables.
+func resolveFileValue(lc logger.LoggingClient, key string, value string) (string, error) {
+	fileKey := key + fileSuffix
+	filePath, found := os.LookupEnv(fileKey)
+	if !found || len(filePath) == 0 {
+		return value, nil
+	}
+
+	content, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		return 0, fmt.Errorf("could not marshal toml configTree to configuration: %s", err.Error())
+		return "", fmt.Errorf("could not read file for environment variable %s: %s", fileKey, err.Error())
+	}
+
+	if len(value) > 0 {
+		logEnvironmentOverride(lc, key, fileKey, fmt.Sprintf("overrides %s=%s", key, value))
 	}
 
-	return overrideCount, nil
+	return strings.TrimRight(string(content), "\r\n\t "), nil
 }
 
-// buildPaths create the path strings for all settings in the Config tree's key map
-func (e *Variables) buildPaths(keyMap map[string]interface{}) []string {
-	var paths []string
+// overrideValue consumes path one element at a time against target, descending into structs and maps,
+// creating missing map entries as it goes, until it reaches a leaf value that it overrides with envValue.
+// It returns nil applied if nothing in target corresponded to path. On success it also returns matchedPath,
+// the same path with every struct field segment replaced by that field's actual name (e.g. "LOGLEVEL"
+// becomes "LogLevel"), since struct fields are matched case-insensitively but a dotted Key built from the
+// raw, all-uppercase environment variable path would never agree with the proper-cased paths BindStruct,
+// FlagSource and leafPaths all use for the same fields - which breaks both provenance reporting and
+// redaction pattern matching (see defaultRedactPatterns). Map key segments are left as-is, since a map has
+// no declared field name to recover the "proper" case from.
+func (e *Variables) overrideValue(target reflect.Value, path []string, envVar string, envValue string) (matchedPath []string, applied *appliedValue, err error) {
+	if len(path) == 0 {
+		return nil, nil, nil
+	}
 
-	for key, item := range keyMap {
-		if reflect.TypeOf(item).Kind() != reflect.Map {
-			paths = append(paths, key)
-			continue
+	target = dereferenceOrAllocate(target)
+	if target.Kind() == reflect.Ptr {
+		return nil, nil, fmt.Errorf("environment variable %s targets a nil pointer that can not be allocated", envVar)
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		field, fieldName, err := matchStructField(target, path[0])
+		if err != nil {
+			return nil, nil, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
+		}
+		if !field.IsValid() {
+			// No field of this service's configuration corresponds to this environment variable.
+			return nil, nil, nil
+		}
+		if len(path) == 1 {
+			applied, err := e.setLeafValue(field, envVar, envValue)
+			if err != nil || applied == nil {
+				return nil, applied, err
+			}
+			return []string{fieldName}, applied, nil
+		}
+		matchedTail, applied, err := e.overrideValue(field, path[1:], envVar, envValue)
+		if err != nil || applied == nil {
+			return nil, applied, err
+		}
+		return append([]string{fieldName}, matchedTail...), applied, nil
+
+	case reflect.Map:
+		return e.overrideMapValue(target, path, envVar, envValue)
+
+	case reflect.Interface:
+		// A struct field or map entry typed as interface{} (commonly used for plugin/driver configuration)
+		// that path still has to descend into; an interface{} that is itself the leaf is set by the caller.
+		// A nil one is given a map[string]interface{} to grow into so the remaining path can be created.
+		if target.IsNil() {
+			if !target.CanSet() {
+				return nil, nil, fmt.Errorf("environment variable %s targets a read-only configuration value", envVar)
+			}
+			target.Set(reflect.ValueOf(map[string]interface{}{}))
+		}
+		return e.overrideValue(target.Elem(), path, envVar, envValue)
+
+	default:
+		return nil, nil, fmt.Errorf(
+			"environment variable %s has more path elements than the configuration structure supports (stopped at a %s value)",
+			envVar, target.Kind())
+	}
+}
+
+// overrideMapValue handles the case where the current path element is a key into a map, creating the map
+// itself and/or the key's entry if either does not already exist.
+func (e *Variables) overrideMapValue(target reflect.Value, path []string, envVar string, envValue string) (matchedPath []string, applied *appliedValue, err error) {
+	mapType := target.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return nil, nil, fmt.Errorf("environment variable %s targets a map with unsupported key type %s", envVar, mapType.Key())
+	}
+
+	if target.IsNil() {
+		if !target.CanSet() {
+			return nil, nil, fmt.Errorf("environment variable %s targets a read-only map", envVar)
 		}
+		target.Set(reflect.MakeMap(mapType))
+	}
+
+	mapKey := reflect.ValueOf(path[0]).Convert(mapType.Key())
+	existing := target.MapIndex(mapKey)
 
-		subMap := item.(map[string]interface{})
+	if len(path) == 1 {
+		var oldValue interface{}
+		if existing.IsValid() {
+			oldValue = existing.Interface()
+		}
 
-		subPaths := e.buildPaths(subMap)
-		for _, path := range subPaths {
-			paths = append(paths, fmt.Sprintf("%s.%s", key, path))
+		newValue, err := e.convertToType(oldValue, mapType.Elem(), envValue)
+		if err != nil {
+			return nil, nil, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
 		}
+
+		target.SetMapIndex(mapKey, reflect.ValueOf(newValue))
+		return []string{path[0]}, &appliedValue{
+			previous:    fmt.Sprintf("%v", oldValue),
+			coercedType: reflect.TypeOf(newValue).String(),
+		}, nil
+	}
+
+	elemType := mapType.Elem()
+	elem := reflect.New(elemType).Elem()
+
+	switch {
+	case existing.IsValid():
+		elem.Set(existing)
+	case elemType.Kind() == reflect.Map:
+		elem.Set(reflect.MakeMap(elemType))
+	case elemType.Kind() == reflect.Ptr:
+		elem.Set(reflect.New(elemType.Elem()))
+	case elemType.Kind() == reflect.Struct, elemType.Kind() == reflect.Interface:
+		// zero value is fine; overrideValue allocates/populates whatever it needs below
+	default:
+		return nil, nil, fmt.Errorf(
+			"environment variable %s targets a new entry in a map of %s, which can not be created from the environment",
+			envVar, elemType)
+	}
+
+	matchedTail, applied, err := e.overrideValue(elem, path[1:], envVar, envValue)
+	if err != nil || applied == nil {
+		return nil, applied, err
 	}
 
-	return paths
+	target.SetMapIndex(mapKey, elem)
+	return append([]string{path[0]}, matchedTail...), applied, nil
 }
 
-// buildUppercasePathMap builds a map where the key is the uppercase version of the path
-// and the value is original version of the path
-func (e *Variables) buildUppercasePathMap(paths []string) map[string]string {
-	ucMap := make(map[string]string)
-	for _, path := range paths {
-		ucMap[strings.ToUpper(path)] = path
+// setLeafValue converts envValue to target's type (or, for an empty interface{}, to the type of whatever
+// value it already holds) and sets it. target must be settable.
+func (e *Variables) setLeafValue(target reflect.Value, envVar string, envValue string) (*appliedValue, error) {
+	if !target.CanSet() {
+		return nil, fmt.Errorf("environment variable %s targets a read-only configuration value", envVar)
 	}
 
-	return ucMap
+	var oldValue interface{}
+	if target.Kind() != reflect.Interface || !target.IsNil() {
+		oldValue = target.Interface()
+	}
+
+	newValue, err := e.convertToType(oldValue, target.Type(), envValue)
+	if err != nil {
+		return nil, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
+	}
+
+	target.Set(reflect.ValueOf(newValue))
+	return &appliedValue{
+		previous:    fmt.Sprintf("%v", oldValue),
+		coercedType: reflect.TypeOf(newValue).String(),
+	}, nil
 }
 
-// getKeyForMatchedPath searches for match of the environment variable name with the uppercase path (pathMap keys)
-// If matched found to original path (pathMap values) is returned as the "key"
-// For backward compatibility a case insensitive comparision is currently used.
-// TODO: For release v2.0.0 Change this to NOT check that `envVarName` is uppercase and only compare against uppercase
-//  so only uppercase environment variable names will match.
-func (e *Variables) getKeyForMatchedPath(pathMap map[string]string, envVarName string) (string, bool) {
-	for ucKey, lcKey := range pathMap {
-		compareKey := lcKey
-		if isAllUpperCase(envVarName) {
-			compareKey = ucKey
+// matchStructField finds the field of target (a struct) whose name matches segment case-insensitively,
+// returning its actual declared name alongside it so callers can recover proper casing from an all-
+// uppercase environment variable path segment. It returns the zero Value if no field matches, and an
+// error if more than one field matches - which can only happen if the struct declares fields whose names
+// collide when case is ignored.
+func matchStructField(target reflect.Value, segment string) (reflect.Value, string, error) {
+	targetType := target.Type()
+
+	var match reflect.Value
+	var name string
+	matched := false
+	for i := 0; i < targetType.NumField(); i++ {
+		if !strings.EqualFold(targetType.Field(i).Name, segment) {
+			continue
+		}
+		if matched {
+			return reflect.Value{}, "", fmt.Errorf(
+				"'%s' ambiguously matches more than one field of %s", segment, targetType.Name())
 		}
+		match = target.Field(i)
+		name = targetType.Field(i).Name
+		matched = true
+	}
+
+	return match, name, nil
+}
 
-		if compareKey == envVarName {
-			return lcKey, true
+// dereferenceOrAllocate follows target through any number of pointers, allocating a new zero value for any
+// nil pointer it can set along the way, and returns the pointed-to value.
+func dereferenceOrAllocate(target reflect.Value) reflect.Value {
+	for target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			if !target.CanSet() {
+				return target
+			}
+			target.Set(reflect.New(target.Type().Elem()))
 		}
+		target = target.Elem()
 	}
 
-	return "", false
+	return target
 }
 
 // OverrideConfigProviderInfo overrides the Configuration Provider ServiceConfig values
@@ -199,6 +460,16 @@ func (_ *Variables) OverrideConfigProviderInfo(
 	// If --registry=<url> is used then we must use the <url> for the configuration provider.
 	// TODO: for release v2.0.0 just use envKeyConfigUrl
 	key, url := getEnvironmentValue(envKeyConfigUrl, envKeyRegistryUrl)
+	if len(url) == 0 {
+		// Neither plain form is set, so look for envKeyConfigUrl's _FILE form.
+		key = envKeyConfigUrl
+	}
+
+	url, err := resolveFileValue(lc, key, url)
+	if err != nil {
+		return types.ServiceConfig{}, err
+	}
+
 	if len(url) > 0 {
 		logEnvironmentOverride(lc, "Configuration Provider Information", key, url)
 
@@ -224,59 +495,100 @@ func (_ *Variables) GetRegistryProviderInfoOverride(lc logger.LoggingClient) str
 	return url
 }
 
-// convertToType attempts to convert the string value to the specified type of the old value
-func (_ *Variables) convertToType(oldValue interface{}, value string) (newValue interface{}, err error) {
-	switch oldValue.(type) {
-	case []string:
-		newValue = parseCommaSeparatedSlice(value)
-	case []interface{}:
-		newValue = parseCommaSeparatedSlice(value)
-	case string:
+// convertToType attempts to convert the string value to targetType, or to the type of oldValue when one
+// already exists (e.g. a value already held by an interface{} field or map entry).
+func (e *Variables) convertToType(oldValue interface{}, targetType reflect.Type, value string) (newValue interface{}, err error) {
+	typ := targetType
+	if oldValue != nil {
+		typ = reflect.TypeOf(oldValue)
+	}
+	if typ == nil || typ.Kind() == reflect.Interface {
+		// No existing value and no concrete type to target (e.g. a brand new map[string]interface{}
+		// entry) - default to string, which covers the common case of freeform plugin configuration.
+		typ = reflect.TypeOf("")
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		values := parseCommaSeparatedSlice(value)
+		switch typ.Elem().Kind() {
+		case reflect.String:
+			newValue = values
+		case reflect.Interface:
+			newValue = toInterfaceSlice(values)
+		default:
+			newValue, err = e.convertSlice(typ.Elem(), values)
+		}
+	case reflect.String:
 		newValue = value
-	case bool:
+	case reflect.Bool:
 		newValue, err = strconv.ParseBool(value)
-	case int:
-		newValue, err = strconv.ParseInt(value, 10, strconv.IntSize)
-		newValue = int(newValue.(int64))
-	case int8:
-		newValue, err = strconv.ParseInt(value, 10, 8)
-		newValue = int8(newValue.(int64))
-	case int16:
-		newValue, err = strconv.ParseInt(value, 10, 16)
-		newValue = int16(newValue.(int64))
-	case int32:
-		newValue, err = strconv.ParseInt(value, 10, 32)
-		newValue = int32(newValue.(int64))
-	case int64:
+	case reflect.Int:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, strconv.IntSize)
+		newValue = int(n)
+	case reflect.Int8:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 8)
+		newValue = int8(n)
+	case reflect.Int16:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 16)
+		newValue = int16(n)
+	case reflect.Int32:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, 32)
+		newValue = int32(n)
+	case reflect.Int64:
 		newValue, err = strconv.ParseInt(value, 10, 64)
-	case uint:
-		newValue, err = strconv.ParseUint(value, 10, strconv.IntSize)
-		newValue = uint(newValue.(uint64))
-	case uint8:
-		newValue, err = strconv.ParseUint(value, 10, 8)
-		newValue = uint8(newValue.(uint64))
-	case uint16:
-		newValue, err = strconv.ParseUint(value, 10, 16)
-		newValue = uint16(newValue.(uint64))
-	case uint32:
-		newValue, err = strconv.ParseUint(value, 10, 32)
-		newValue = uint32(newValue.(uint64))
-	case uint64:
+	case reflect.Uint:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, strconv.IntSize)
+		newValue = uint(n)
+	case reflect.Uint8:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 8)
+		newValue = uint8(n)
+	case reflect.Uint16:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 16)
+		newValue = uint16(n)
+	case reflect.Uint32:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, 32)
+		newValue = uint32(n)
+	case reflect.Uint64:
 		newValue, err = strconv.ParseUint(value, 10, 64)
-	case float32:
-		newValue, err = strconv.ParseFloat(value, 32)
-		newValue = float32(newValue.(float64))
-	case float64:
+	case reflect.Float32:
+		var f float64
+		f, err = strconv.ParseFloat(value, 32)
+		newValue = float32(f)
+	case reflect.Float64:
 		newValue, err = strconv.ParseFloat(value, 64)
 	default:
 		err = fmt.Errorf(
-			"configuration type of '%s' is not supported for environment variable override",
-			reflect.TypeOf(oldValue).String())
+			"configuration type of '%s' is not supported for environment variable override", typ.String())
 	}
 
 	return newValue, err
 }
 
+// convertSlice converts each comma-separated element in raw to elemType and returns the properly-typed
+// slice (e.g. []int, not []interface{}), so the result is assignable via reflect.Value.Set into a field
+// or map entry declared as a slice of something other than string or interface{}.
+func (e *Variables) convertSlice(elemType reflect.Type, raw []string) (interface{}, error) {
+	slice := reflect.MakeSlice(reflect.SliceOf(elemType), len(raw), len(raw))
+	for i, item := range raw {
+		elemValue, err := e.convertToType(nil, elemType, item)
+		if err != nil {
+			return nil, err
+		}
+		slice.Index(i).Set(reflect.ValueOf(elemValue))
+	}
+
+	return slice.Interface(), nil
+}
+
 // StartupInfo provides the startup timer values which are applied to the StartupTimer created at boot.
 type StartupInfo struct {
 	Duration int
@@ -322,8 +634,10 @@ func GetStartupInfo(serviceKey string) StartupInfo {
 // GetConfDir get the config directory value from an Variables variable value (if it exists)
 // or uses passed in value or default if previous result in blank.
 func GetConfDir(lc logger.LoggingClient, configDir string) string {
-	envValue := os.Getenv(envConfDir)
-	if len(envValue) > 0 {
+	envValue, err := resolveFileValue(lc, envConfDir, os.Getenv(envConfDir))
+	if err != nil {
+		lc.Error(err.Error())
+	} else if len(envValue) > 0 {
 		configDir = envValue
 		logEnvironmentOverride(lc, "-c/-confdir", envFile, envValue)
 	}
@@ -340,7 +654,15 @@ func GetConfDir(lc logger.LoggingClient, configDir string) string {
 func GetProfileDir(lc logger.LoggingClient, profileDir string) string {
 	// TODO: For release v2.0.0 just use envProfile
 	key, envValue := getEnvironmentValue(envProfile, envV1Profile)
-	if len(envValue) > 0 {
+	if len(envValue) == 0 {
+		// Neither plain form is set, so look for envProfile's _FILE form.
+		key = envProfile
+	}
+
+	envValue, err := resolveFileValue(lc, key, envValue)
+	if err != nil {
+		lc.Error(err.Error())
+	} else if len(envValue) > 0 {
 		profileDir = envValue
 		logEnvironmentOverride(lc, "-p/-profile", key, envValue)
 	}
@@ -355,8 +677,10 @@ func GetProfileDir(lc logger.LoggingClient, profileDir string) string {
 // GetConfigFileName gets the configuration filename value from an Variables variable value (if it exists)
 // or uses passed in value.
 func GetConfigFileName(lc logger.LoggingClient, configFileName string) string {
-	envValue := os.Getenv(envFile)
-	if len(envValue) > 0 {
+	envValue, err := resolveFileValue(lc, envFile, os.Getenv(envFile))
+	if err != nil {
+		lc.Error(err.Error())
+	} else if len(envValue) > 0 {
 		configFileName = envValue
 		logEnvironmentOverride(lc, "-f/-file", envFile, envValue)
 	}
@@ -365,7 +689,7 @@ func GetConfigFileName(lc logger.LoggingClient, configFileName string) string {
 }
 
 // parseCommaSeparatedSlice converts comma separated list to a string slice
-func parseCommaSeparatedSlice(value string) (values []interface{}) {
+func parseCommaSeparatedSlice(value string) (values []string) {
 	// Assumption is environment variable value is comma separated
 	// Whitespace can vary so must be trimmed out
 	result := strings.Split(strings.TrimSpace(value), ",")
@@ -376,6 +700,17 @@ func parseCommaSeparatedSlice(value string) (values []interface{}) {
 	return values
 }
 
+// toInterfaceSlice re-boxes a []string as a []interface{}, for overriding fields/map entries declared as
+// []interface{} rather than []string.
+func toInterfaceSlice(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
+
 // TODO: Remove for release v2.0.0
 // getEnvironmentValue attempt to get value for new upper case key and if not found attempts
 // to get value for old lower case key. Returns the key last attempted and value from last attempt
@@ -393,14 +728,3 @@ func getEnvironmentValue(newKey string, v1Key string) (string, string) {
 func logEnvironmentOverride(lc logger.LoggingClient, name string, key string, value string) {
 	lc.Info(fmt.Sprintf("Variables override of '%s' by environment variable: %s=%s", name, key, value))
 }
-
-// isAllUpperCase checks the key to determine if it is all uppercase letters
-func isAllUpperCase(key string) bool {
-	for _, ch := range key {
-		if unicode.IsLetter(ch) && !unicode.IsUpper(ch) {
-			return false
-		}
-	}
-
-	return true
-}
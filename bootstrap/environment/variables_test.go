@@ -0,0 +1,163 @@
+/*******************************************************************************
+ * Copyright 2020 Intel Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSecretData struct {
+	Path    string
+	Secrets map[string]string
+}
+
+type testWritable struct {
+	LogLevel        string
+	InsecureSecrets map[string]testSecretData
+}
+
+type testConfig struct {
+	Writable testWritable
+	Driver   interface{}
+}
+
+// ambiguousConfig has two fields that only differ by case, the only way matchStructField can see more
+// than one case-insensitive match for a single path segment.
+type ambiguousConfig struct {
+	Port int
+	PORT int
+}
+
+func newTestVariables() *Variables {
+	return &Variables{redactPatterns: defaultRedactPatterns}
+}
+
+func TestOverrideValueCreatesNewMapEntry(t *testing.T) {
+	e := newTestVariables()
+	cfg := &testConfig{}
+
+	matchedPath, applied, err := e.overrideValue(
+		reflect.ValueOf(cfg).Elem(),
+		[]string{"Writable", "InsecureSecrets", "DB", "Path"},
+		"EDGEX_WRITABLE_INSECURESECRETS_DB_PATH",
+		"/tmp/secret",
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, applied)
+	assert.Equal(t, []string{"Writable", "InsecureSecrets", "DB", "Path"}, matchedPath)
+	require.Contains(t, cfg.Writable.InsecureSecrets, "DB")
+	assert.Equal(t, "/tmp/secret", cfg.Writable.InsecureSecrets["DB"].Path)
+}
+
+func TestOverrideValueLeafInterfaceField(t *testing.T) {
+	e := newTestVariables()
+	cfg := &testConfig{}
+
+	_, applied, err := e.overrideValue(
+		reflect.ValueOf(cfg).Elem(),
+		[]string{"Driver"},
+		"EDGEX_DRIVER",
+		"usb",
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, applied)
+	assert.Equal(t, "usb", cfg.Driver)
+}
+
+func TestOverrideValueDescendsIntoNilInterfaceField(t *testing.T) {
+	e := newTestVariables()
+	cfg := &testConfig{}
+
+	_, applied, err := e.overrideValue(
+		reflect.ValueOf(cfg).Elem(),
+		[]string{"Driver", "Address"},
+		"EDGEX_DRIVER_ADDRESS",
+		"10.0.0.1",
+	)
+
+	require.NoError(t, err)
+	require.NotNil(t, applied)
+	driverMap, ok := cfg.Driver.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.1", driverMap["Address"])
+}
+
+func TestOverrideValueAmbiguousFieldMatchFails(t *testing.T) {
+	e := newTestVariables()
+	cfg := &ambiguousConfig{}
+
+	_, _, err := e.overrideValue(
+		reflect.ValueOf(cfg).Elem(),
+		[]string{"PORT"},
+		"EDGEX_PORT",
+		"8080",
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambiguously")
+}
+
+func TestOverrideValueDescendingIntoScalarFails(t *testing.T) {
+	e := newTestVariables()
+	cfg := &testConfig{}
+
+	_, _, err := e.overrideValue(
+		reflect.ValueOf(cfg).Elem(),
+		[]string{"Writable", "LogLevel", "Extra"},
+		"EDGEX_WRITABLE_LOGLEVEL_EXTRA",
+		"debug",
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "more path elements")
+}
+
+func TestOverrideValueUnmatchedFieldIsNotAnError(t *testing.T) {
+	e := newTestVariables()
+	cfg := &testConfig{}
+
+	_, applied, err := e.overrideValue(
+		reflect.ValueOf(cfg).Elem(),
+		[]string{"DoesNotExist"},
+		"EDGEX_DOESNOTEXIST",
+		"value",
+	)
+
+	require.NoError(t, err)
+	assert.Nil(t, applied)
+}
+
+func TestConvertToTypeConvertsNonStringSlice(t *testing.T) {
+	e := newTestVariables()
+
+	newValue, err := e.convertToType(nil, reflect.TypeOf([]int{}), "1, 2, 3")
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, newValue)
+}
+
+func TestConvertToTypeUnsupportedSliceElementFails(t *testing.T) {
+	e := newTestVariables()
+
+	_, err := e.convertToType(nil, reflect.TypeOf([]testSecretData{}), "a,b")
+
+	require.Error(t, err)
+}